@@ -0,0 +1,115 @@
+/*
+ * Copyright 2024 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package indexer
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// EmitCaptures walks file, emitting a captures edge from each function
+// literal to every outer variable it closes over, plus a
+// ref/writes/captures edge at every assignment or increment/decrement
+// inside the literal whose target is such a variable.
+func EmitCaptures(info *types.Info, file *ast.File, emit Emitter) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.FuncLit); ok {
+			visitFuncLit(info, lit, emit)
+		}
+		return true
+	})
+}
+
+// visitFuncLit reports the edges for a single function literal. Nested
+// literals are not walked here: EmitCaptures' outer traversal reaches them
+// directly and calls visitFuncLit on each in turn.
+func visitFuncLit(info *types.Info, lit *ast.FuncLit, emit Emitter) {
+	captured := map[*types.Var]bool{}
+	note := func(ident *ast.Ident) *types.Var {
+		v, ok := info.Uses[ident].(*types.Var)
+		if !ok || v.IsField() || !isCapture(info, lit, v) {
+			return nil
+		}
+		if !captured[v] {
+			captured[v] = true
+			emit.Edge(EdgeCaptures, lit, v)
+		}
+		return v
+	}
+
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false // handled independently by the outer traversal
+
+		case *ast.AssignStmt:
+			for _, lhs := range s.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					noteCaptureWrite(note, emit, ident)
+				}
+			}
+
+		case *ast.IncDecStmt:
+			if ident, ok := s.X.(*ast.Ident); ok {
+				noteCaptureWrite(note, emit, ident)
+			}
+
+		case *ast.Ident:
+			note(s)
+		}
+		return true
+	})
+}
+
+// noteCaptureWrite records a write-site identifier (the target of an
+// assignment or increment/decrement) as a capture if it resolves to one,
+// and, if so, emits the accompanying ref/writes/captures edge.
+func noteCaptureWrite(note func(*ast.Ident) *types.Var, emit Emitter, ident *ast.Ident) {
+	if v := note(ident); v != nil {
+		emit.Edge(EdgeRefWritesCaptures, ident, v)
+	}
+}
+
+// isCapture reports whether v, used inside lit, was declared in a function
+// lexically enclosing lit rather than inside lit itself or at package
+// scope. It compares v's declaring scope against lit's own scope and walks
+// the scope chain in between, per the lexical-scope approach described in
+// the Go types package: http://pkg.go.dev/go/types#Info.Scopes.
+func isCapture(info *types.Info, lit *ast.FuncLit, v *types.Var) bool {
+	litScope := info.Scopes[lit.Type]
+	if litScope == nil {
+		return false
+	}
+	if litScope.Contains(v.Pos()) {
+		return false // a parameter or local of lit itself
+	}
+
+	vScope := v.Parent()
+	if vScope == nil || vScope.Parent() == types.Universe {
+		return false // no scope, or declared at package scope
+	}
+
+	for s := litScope.Parent(); s != nil; s = s.Parent() {
+		if s == vScope {
+			return true
+		}
+		if s.Parent() == types.Universe {
+			break // reached package scope without finding vScope
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2024 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package indexer
+
+import "testing"
+
+func TestEmitCaptures(t *testing.T) {
+	const src = `package p
+
+func f() {
+	var x int
+	var y int
+
+	read := func() int {
+		return x
+	}
+	read()
+
+	write := func() {
+		y = 1
+	}
+	write()
+
+	go func() {
+		y = 2
+	}()
+
+	defer func() {
+		y = 3
+	}()
+
+	compound := func() {
+		y += 1
+	}
+	compound()
+
+	incdec := func() {
+		y++
+	}
+	incdec()
+
+	_ = x
+	_ = y
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitCaptures(info, file, &c)
+
+	if n := countEdges(c.edges, EdgeCaptures, "func", "x"); n != 1 {
+		t.Errorf("want exactly 1 captures edge to x (read-only closure), got %d in %+v", n, c.edges)
+	}
+	if n := countEdges(c.edges, EdgeCaptures, "func", "y"); n != 5 {
+		t.Errorf("want 5 captures edges to y (write, goroutine, defer, compound, incdec), got %d in %+v", n, c.edges)
+	}
+	if n := countEdges(c.edges, EdgeRefWritesCaptures, "y", "y"); n != 5 {
+		t.Errorf("want 5 ref/writes/captures edges on y, got %d in %+v", n, c.edges)
+	}
+	if countEdges(c.edges, EdgeRefWritesCaptures, "x", "x") != 0 {
+		t.Errorf("x is only ever read, should not get a ref/writes/captures edge, got %+v", c.edges)
+	}
+}
+
+func TestEmitCapturesCompoundAndIncDec(t *testing.T) {
+	const src = `package p
+
+func f() {
+	var a int
+	var b int
+
+	compound := func() {
+		a += 1
+	}
+	compound()
+
+	incdec := func() {
+		b++
+	}
+	incdec()
+
+	_ = a
+	_ = b
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitCaptures(info, file, &c)
+
+	if !hasEdge(c.edges, EdgeRefWritesCaptures, "a", "a") {
+		t.Errorf("want ref/writes/captures for compound-assignment capture, got %+v", c.edges)
+	}
+	if !hasEdge(c.edges, EdgeRefWritesCaptures, "b", "b") {
+		t.Errorf("want ref/writes/captures for increment capture, got %+v", c.edges)
+	}
+}
+
+func countEdges(edges []gotEdge, kind, anchor, target string) int {
+	n := 0
+	for _, e := range edges {
+		if e.kind == kind && e.anchor == anchor && e.target == target {
+			n++
+		}
+	}
+	return n
+}
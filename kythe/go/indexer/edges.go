@@ -0,0 +1,47 @@
+/*
+ * Copyright 2024 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package indexer
+
+// Edge kinds emitted by the write- and capture-tracking passes in this
+// package. These extend the base "ref" and "ref/writes" edges that the rest
+// of the indexer already produces for plain reads and assignments.
+const (
+	// EdgeRef marks an anchor that reads the value of a variable or field.
+	EdgeRef = "ref"
+
+	// EdgeRefWrites marks an anchor that assigns a new value to a variable
+	// or field, without regard to whether the previous value was read.
+	EdgeRefWrites = "ref/writes"
+
+	// EdgeRefWritesInit marks a write that establishes a variable or field's
+	// initial value: a composite literal key, a ":=" short declaration, or a
+	// "var" declaration with an initializer.
+	EdgeRefWritesInit = "ref/writes/init"
+
+	// EdgeRefWritesField marks an intermediate field in a selector chain
+	// (e.g. Nested in z.Nested.F = 52) as mutated-through: the field itself
+	// is not overwritten, but the value it points to or contains was.
+	EdgeRefWritesField = "ref/writes/field"
+
+	// EdgeRefWritesCaptures marks a write to a variable captured from an
+	// enclosing function by a function literal.
+	EdgeRefWritesCaptures = "ref/writes/captures"
+
+	// EdgeCaptures connects a function literal to each outer variable it
+	// closes over.
+	EdgeCaptures = "captures"
+)
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2024 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package indexer
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// visitValueSpec handles a "var w = ..." declaration (including grouped
+// forms like "var a, b = 1, 2"). A ValueSpec with no Values is a bare
+// declaration ("var x int") and contributes no write. ValueSpec is also used
+// for "const" declarations, which share the same AST shape but resolve to
+// *types.Const rather than *types.Var, so those are excluded: a constant
+// can't be written to, initially or otherwise. The declared name itself is
+// an initializing write; any nested composite literal keys in its
+// initializer are classified separately by visitCompositeLit.
+func visitValueSpec(info *types.Info, spec *ast.ValueSpec, emit Emitter) {
+	if len(spec.Values) == 0 {
+		return
+	}
+	for _, name := range spec.Names {
+		if name.Name == "_" {
+			continue
+		}
+		if v, ok := info.Defs[name].(*types.Var); ok {
+			emit.Edge(EdgeRefWritesInit, name, v)
+		}
+	}
+}
+
+// visitCompositeLit classifies each keyed field of a struct literal (e.g.
+// the Nested and F keys in S{Nested: &S{}}) as an initializing write: the
+// field is being given its starting value, not mutated after construction.
+// Keys are resolved via info.Uses, per the field-resolution rule that
+// go/types applies to KeyValueExpr keys in struct literals. Composite
+// literals for other types (maps and arrays keyed by constants, e.g.
+// map[Enum]int{ValA: 1}) use the same KeyValueExpr shape but their keys are
+// ordinary values, not fields, so those are excluded by requiring the
+// resolved key to be a struct field.
+func visitCompositeLit(info *types.Info, lit *ast.CompositeLit, emit Emitter) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		v, ok := info.Uses[key].(*types.Var)
+		if !ok || !v.IsField() {
+			continue
+		}
+		emit.Edge(EdgeRefWritesInit, key, v)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2024 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package indexer
+
+import "testing"
+
+func TestEmitWritesInitClassification(t *testing.T) {
+	const src = `package p
+
+type S struct {
+	F      int
+	Nested *S
+}
+
+func f() {
+	z := S{Nested: &S{}}
+
+	var w = S{F: 1}
+	_ = w
+
+	z.F = 42
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitWrites(info, file, &c)
+
+	for _, want := range []gotEdge{
+		{EdgeRefWritesInit, "z", "z"},
+		{EdgeRefWritesInit, "Nested", "Nested"},
+		{EdgeRefWritesInit, "w", "w"},
+		{EdgeRefWritesInit, "F", "F"},
+	} {
+		if !hasEdge(c.edges, want.kind, want.anchor, want.target) {
+			t.Errorf("missing init edge %+v, got %+v", want, c.edges)
+		}
+	}
+
+	// The later z.F = 42 is a mutation, not an init: it must not also be
+	// reported as ref/writes/init.
+	if countEdges(c.edges, EdgeRefWritesInit, "F", "F") != 1 {
+		t.Errorf("z.F = 42 must not be classified as an init write, got %+v", c.edges)
+	}
+	if !hasEdge(c.edges, EdgeRefWrites, "F", "F") {
+		t.Errorf("want a plain ref/writes for the z.F = 42 mutation, got %+v", c.edges)
+	}
+}
+
+func TestEmitWritesIgnoresMapLiteralKeys(t *testing.T) {
+	const src = `package p
+
+type Enum int
+
+const (
+	ValA Enum = iota
+	ValB
+)
+
+func f() {
+	m := map[Enum]int{ValA: 1, ValB: 2}
+	_ = m
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitWrites(info, file, &c)
+
+	for _, name := range []string{"ValA", "ValB"} {
+		if hasEdge(c.edges, EdgeRefWritesInit, name, name) {
+			t.Errorf("map literal key %s is a constant, not a struct field; must not get ref/writes/init, got %+v", name, c.edges)
+		}
+	}
+}
+
+func TestEmitWritesIgnoresConstDecls(t *testing.T) {
+	const src = `package p
+
+const Foo = 5
+
+func f() {
+	const Bar = 10
+	_ = Bar
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitWrites(info, file, &c)
+
+	for _, name := range []string{"Foo", "Bar"} {
+		if hasEdge(c.edges, EdgeRefWritesInit, name, name) {
+			t.Errorf("%s is a constant; must not get ref/writes/init, got %+v", name, c.edges)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package captures tests ref/writes/captures and captures edges for
+// variables closed over by function literals.
+package captures
+
+func f() {
+	//- @x defines/binding X
+	var x int
+
+	//- @#0y defines/binding Y
+	var y int
+
+	//- @read defines/binding Read
+	//- @"func" captures X
+	read := func() int {
+		//- @x ref X
+		return x
+	}
+	//- @read ref Read
+	read()
+
+	//- @write defines/binding Write
+	//- @"func" captures Y
+	write := func() {
+		//- @y ref/writes/captures Y
+		y = 1
+	}
+	//- @write ref Write
+	write()
+
+	//- @"func" captures Y
+	go func() {
+		//- @y ref/writes/captures Y
+		y = 2
+	}()
+
+	//- @"func" captures Y
+	defer func() {
+		//- @y ref/writes/captures Y
+		y = 3
+	}()
+
+	//- @compound defines/binding Compound
+	//- @"func" captures Y
+	compound := func() {
+		//- @y ref/writes/captures Y
+		y += 1
+	}
+	//- @compound ref Compound
+	compound()
+
+	//- @incdec defines/binding Incdec
+	//- @"func" captures Y
+	incdec := func() {
+		//- @y ref/writes/captures Y
+		y++
+	}
+	//- @incdec ref Incdec
+	incdec()
+
+	_ = x
+	_ = y
+}
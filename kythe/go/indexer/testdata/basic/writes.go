@@ -30,13 +30,77 @@ func f() {
 	for i := 0; i < 10; i = i + 1 {
 	}
 
+	//- @y ref Y
+	//- @y ref/writes Y
+	y += 3
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y -= 1
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y *= 2
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y /= 2
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y %= 2
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y &= 1
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y |= 1
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y ^= 1
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y <<= 1
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y >>= 1
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y &^= 1
+
+	//- @y ref Y
+	//- @y ref/writes Y
+	y--
+
+	//- @p defines/binding P
+	p := &y
+	//- @p ref P
+	//- @p ref/writes Y
+	*p = 7
+
 	//- @z defines/binding Z
+	//- @z ref/writes/init Z
+	//- @Nested ref/writes/init Nested
 	z := S{Nested: &S{}}
+
+	//- @w defines/binding W
+	//- @w ref/writes/init W
+	//- @F ref/writes/init F
+	var w = S{F: 1}
+	_ = w
+
 	//- @z ref Z
 	//- @F ref/writes F
 	z.F = 42
 	//- @z ref Z
 	//- @Nested ref Nested
+	//- @Nested ref/writes/field Nested
 	//- @F ref/writes F
 	z.Nested.F = 52
 
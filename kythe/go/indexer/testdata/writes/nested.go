@@ -0,0 +1,49 @@
+// Package nested tests recursive ref/writes edges through selector chains,
+// map index writes, and slice/array index writes.
+package nested
+
+type Inner struct {
+	//- @D defines/binding D
+	D int
+}
+
+type Middle struct {
+	//- @C defines/binding C
+	C Inner
+}
+
+type Outer struct {
+	//- @B defines/binding B
+	B Middle
+}
+
+func f() {
+	//- @a defines/binding A
+	var a Outer
+
+	//- @a ref A
+	//- @B ref B
+	//- @B ref/writes/field B
+	//- @C ref C
+	//- @C ref/writes/field C
+	//- @D ref/writes D
+	a.B.C.D = 1
+
+	//- @m defines/binding M
+	m := map[string]int{}
+	//- @m ref M
+	//- @m ref/writes M
+	m["k"] = 2
+
+	//- @s defines/binding Sl
+	s := make([]int, 3)
+	//- @s ref Sl
+	//- @s ref/writes Sl
+	s[0] = 3
+
+	//- @arr defines/binding Arr
+	var arr [3]int
+	//- @arr ref Arr
+	//- @arr ref/writes Arr
+	arr[1] = 4
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright 2024 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package indexer analyzes the assignment and mutation shapes of a type-
+// checked Go file and reports the ref/writes family of edges described at
+// http://kythe.io/docs/schema/writeref.html.
+package indexer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Emitter receives the edges discovered while walking a file. anchor is the
+// syntax node whose source span should carry the edge; obj is the variable
+// or field the edge targets.
+type Emitter interface {
+	Edge(kind string, anchor ast.Node, obj types.Object)
+}
+
+// EmitWrites walks file, emitting the ref/writes family of edges for every
+// assignment and increment/decrement statement it finds. info must be the
+// *types.Info populated for file by a prior call to (*types.Config).Check,
+// with at least Defs and Uses filled in.
+func EmitWrites(info *types.Info, file *ast.File, emit Emitter) {
+	aliases := map[types.Object]types.Object{} // pointer var -> addressed var
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			visitAssign(info, stmt, aliases, emit)
+		case *ast.IncDecStmt:
+			writeTarget(info, stmt.X, aliases, emit, true)
+		case *ast.ValueSpec:
+			visitValueSpec(info, stmt, emit)
+		case *ast.CompositeLit:
+			visitCompositeLit(info, stmt, emit)
+		}
+		return true
+	})
+}
+
+func visitAssign(info *types.Info, stmt *ast.AssignStmt, aliases map[types.Object]types.Object, emit Emitter) {
+	compound := isCompoundTok(stmt.Tok)
+
+	for i, lhs := range stmt.Lhs {
+		recordAlias(info, lhs, rhsFor(stmt, i), aliases)
+
+		if stmt.Tok == token.DEFINE {
+			visitDefine(info, lhs, emit)
+			continue
+		}
+		writeTarget(info, lhs, aliases, emit, compound)
+	}
+}
+
+// visitDefine handles one left-hand side of a ":=" statement. A name that is
+// genuinely new in this scope is an initializing write; a name that already
+// existed in an outer scope (legal in a ":=" so long as at least one name on
+// the line is new) is a plain mutating write.
+func visitDefine(info *types.Info, lhs ast.Expr, emit Emitter) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+	if obj := info.Defs[ident]; obj != nil {
+		emit.Edge(EdgeRefWritesInit, ident, obj)
+		return
+	}
+	if obj := info.ObjectOf(ident); obj != nil {
+		emit.Edge(EdgeRefWrites, ident, obj)
+	}
+}
+
+// writeTarget emits the write (and, for compound operators, the
+// accompanying read) edge for a single assignment or increment/decrement
+// target.
+func writeTarget(info *types.Info, lhs ast.Expr, aliases map[types.Object]types.Object, emit Emitter, compound bool) {
+	switch e := lhs.(type) {
+	case *ast.Ident:
+		if e.Name == "_" {
+			return
+		}
+		if obj := info.ObjectOf(e); obj != nil {
+			emitWrite(emit, e, obj, compound)
+		}
+
+	case *ast.StarExpr:
+		ptr, ok := e.X.(*ast.Ident)
+		if !ok {
+			return
+		}
+		obj := info.ObjectOf(ptr)
+		if obj == nil {
+			return
+		}
+		if target, ok := aliases[obj]; ok {
+			obj = target
+		}
+		emitWrite(emit, ptr, obj, compound)
+
+	case *ast.SelectorExpr:
+		writeSelectorChain(info, e, emit, compound)
+
+	case *ast.IndexExpr:
+		base, ok := e.X.(*ast.Ident)
+		if !ok {
+			return
+		}
+		if obj := info.ObjectOf(base); obj != nil {
+			emitWrite(emit, base, obj, compound)
+		}
+	}
+}
+
+// writeSelectorChain handles a write through a (possibly multi-level)
+// selector expression such as "z.Nested.F = 52". The final field is the
+// write target; every field in between is marked with EdgeRefWritesField,
+// since the struct it belongs to was not itself overwritten but its state
+// changed through it.
+func writeSelectorChain(info *types.Info, sel *ast.SelectorExpr, emit Emitter, compound bool) {
+	final := info.ObjectOf(sel.Sel)
+	if final == nil {
+		return
+	}
+	emitWrite(emit, sel.Sel, final, compound)
+
+	for cur := sel.X; ; {
+		inner, ok := cur.(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		if obj := info.ObjectOf(inner.Sel); obj != nil {
+			emit.Edge(EdgeRefWritesField, inner.Sel, obj)
+		}
+		cur = inner.X
+	}
+}
+
+func emitWrite(emit Emitter, anchor ast.Node, obj types.Object, compound bool) {
+	if compound {
+		emit.Edge(EdgeRef, anchor, obj)
+	}
+	emit.Edge(EdgeRefWrites, anchor, obj)
+}
+
+// recordAlias remembers simple, non-escaping "p := &y" / "p = &y" address-of
+// assignments so that a later "*p = v" can be attributed to y rather than to
+// p itself. This is a best-effort local heuristic, not general pointer
+// analysis: aliases formed any other way are not tracked.
+func recordAlias(info *types.Info, lhs, rhs ast.Expr, aliases map[types.Object]types.Object) {
+	if rhs == nil {
+		return
+	}
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return
+	}
+	unary, ok := rhs.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return
+	}
+	target, ok := unary.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	ptrObj, targetObj := info.ObjectOf(ident), info.ObjectOf(target)
+	if ptrObj != nil && targetObj != nil {
+		aliases[ptrObj] = targetObj
+	}
+}
+
+// rhsFor returns the right-hand side expression that initializes stmt's i'th
+// left-hand side, or nil if the shape of stmt (e.g. "a, b := f()") doesn't
+// pair them up one-to-one.
+func rhsFor(stmt *ast.AssignStmt, i int) ast.Expr {
+	if len(stmt.Rhs) == len(stmt.Lhs) {
+		return stmt.Rhs[i]
+	}
+	return nil
+}
+
+func isCompoundTok(tok token.Token) bool {
+	switch tok {
+	case token.ADD_ASSIGN, token.SUB_ASSIGN, token.MUL_ASSIGN, token.QUO_ASSIGN,
+		token.REM_ASSIGN, token.AND_ASSIGN, token.OR_ASSIGN, token.XOR_ASSIGN,
+		token.SHL_ASSIGN, token.SHR_ASSIGN, token.AND_NOT_ASSIGN:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2024 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package indexer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+type gotEdge struct {
+	kind   string
+	anchor string
+	target string
+}
+
+type collector struct{ edges []gotEdge }
+
+func (c *collector) Edge(kind string, anchor ast.Node, obj types.Object) {
+	name := "?"
+	switch a := anchor.(type) {
+	case *ast.Ident:
+		name = a.Name
+	case *ast.FuncLit:
+		name = "func"
+	}
+	c.edges = append(c.edges, gotEdge{kind, name, obj.Name()})
+}
+
+func parseAndCheck(t *testing.T, src string) (*ast.File, *types.Info) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	info := &types.Info{
+		Defs:   map[*ast.Ident]types.Object{},
+		Uses:   map[*ast.Ident]types.Object{},
+		Scopes: map[ast.Node]*types.Scope{},
+	}
+	var conf types.Config
+	if _, err := conf.Check("test", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	return file, info
+}
+
+func hasEdge(edges []gotEdge, kind, anchor, target string) bool {
+	for _, e := range edges {
+		if e.kind == kind && e.anchor == anchor && e.target == target {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEmitWritesCompoundAndUnary(t *testing.T) {
+	const src = `package p
+
+func f() {
+	y := 2
+	y++
+	y--
+	y += 3
+	y -= 1
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitWrites(info, file, &c)
+
+	for _, kind := range []string{EdgeRef, EdgeRefWrites} {
+		if !hasEdge(c.edges, kind, "y", "y") {
+			t.Errorf("missing %s edge anchored at y, got %+v", kind, c.edges)
+		}
+	}
+}
+
+func TestEmitWritesPointerIndirection(t *testing.T) {
+	const src = `package p
+
+func f() {
+	y := 2
+	p := &y
+	*p = 7
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitWrites(info, file, &c)
+
+	if !hasEdge(c.edges, EdgeRefWrites, "p", "y") {
+		t.Errorf("want ref/writes anchored at p targeting y, got %+v", c.edges)
+	}
+	if hasEdge(c.edges, EdgeRefWrites, "y", "y") {
+		t.Errorf("did not expect a write anchored directly at y, got %+v", c.edges)
+	}
+}
+
+func TestEmitWritesReuseInShortDecl(t *testing.T) {
+	const src = `package p
+
+func f() {
+	var x int
+	x, y := 1, 2
+	_, _ = x, y
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitWrites(info, file, &c)
+
+	if !hasEdge(c.edges, EdgeRefWrites, "x", "x") {
+		t.Errorf("want ref/writes on reused x, got %+v", c.edges)
+	}
+	if hasEdge(c.edges, EdgeRefWrites, "y", "y") {
+		t.Errorf("y is freshly declared here, not a plain write, got %+v", c.edges)
+	}
+}
+
+func TestEmitWritesNestedSelectorChain(t *testing.T) {
+	const src = `package p
+
+type Inner struct{ D int }
+type Middle struct{ C Inner }
+type Outer struct{ B Middle }
+
+func f() {
+	var a Outer
+	a.B.C.D = 1
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitWrites(info, file, &c)
+
+	if !hasEdge(c.edges, EdgeRefWrites, "D", "D") {
+		t.Errorf("want ref/writes on the final field D, got %+v", c.edges)
+	}
+	for _, field := range []string{"B", "C"} {
+		if !hasEdge(c.edges, EdgeRefWritesField, field, field) {
+			t.Errorf("want ref/writes/field on intermediate field %s, got %+v", field, c.edges)
+		}
+	}
+}
+
+func TestEmitWritesMapAndIndex(t *testing.T) {
+	const src = `package p
+
+func f() {
+	m := map[string]int{}
+	m["k"] = 2
+
+	s := make([]int, 3)
+	s[0] = 3
+
+	var arr [3]int
+	arr[1] = 4
+}
+`
+	file, info := parseAndCheck(t, src)
+	var c collector
+	EmitWrites(info, file, &c)
+
+	for _, name := range []string{"m", "s", "arr"} {
+		if !hasEdge(c.edges, EdgeRefWrites, name, name) {
+			t.Errorf("want ref/writes on indexed base %s, got %+v", name, c.edges)
+		}
+	}
+}